@@ -0,0 +1,186 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AppState is the current lifecycle state of the cluster.
+type AppState string
+
+const (
+	AppStateInit  AppState = "initializing"
+	AppStateReady AppState = "ready"
+	AppStateError AppState = "error"
+)
+
+// ClusterRole identifies the role a replset plays in a sharded topology.
+type ClusterRole string
+
+const (
+	ClusterRoleShardSvr  ClusterRole = "shardsvr"
+	ClusterRoleConfigSvr ClusterRole = "configsvr"
+)
+
+// ReplsetSpec defines the configuration of a single replica set.
+type ReplsetSpec struct {
+	Name        string      `json:"name"`
+	Size        int32       `json:"size"`
+	ClusterRole ClusterRole `json:"clusterRole,omitempty"`
+}
+
+// MongosSpec defines the configuration of the mongos router deployment.
+type MongosSpec struct {
+	Size int32 `json:"size,omitempty"`
+}
+
+// ShardingSpec configures a sharded cluster topology.
+type ShardingSpec struct {
+	Enabled          bool         `json:"enabled,omitempty"`
+	ConfigsvrReplSet *ReplsetSpec `json:"configsvrReplSet,omitempty"`
+	Mongos           *MongosSpec  `json:"mongos,omitempty"`
+}
+
+// PMMSpec configures the Percona Monitoring and Management sidecar.
+type PMMSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// SecretsSpec defines the names of Secrets the operator reads credentials from.
+type SecretsSpec struct {
+	Users         string `json:"users,omitempty"`
+	EncryptionKey string `json:"encryptionKey,omitempty"`
+
+	// ExternalSource, when set, tells the operator to source the Users
+	// secret's contents from an external secret store instead of reading
+	// the corev1.Secret named above directly.
+	ExternalSource *ExternalSecretSource `json:"externalSource,omitempty"`
+
+	// PasswordRotation, when enabled, has the operator generate and apply
+	// fresh passwords for system users on a schedule.
+	PasswordRotation *PasswordRotationSpec `json:"passwordRotation,omitempty"`
+}
+
+// PasswordRotationSpec configures scheduled automatic password rotation for
+// system users.
+type PasswordRotationSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Interval is how often a user's password is rotated.
+	Interval metav1.Duration `json:"interval,omitempty"`
+
+	// Length is the generated password length. Defaults to 20.
+	Length int `json:"length,omitempty"`
+
+	// Users lists which system users to rotate: "backup", "clusterAdmin",
+	// "clusterMonitor", "userAdmin", "pmm". Defaults to all of them.
+	Users []string `json:"users,omitempty"`
+}
+
+// ExternalSecretSourceType identifies which backend an ExternalSecretSource talks to.
+type ExternalSecretSourceType string
+
+const (
+	ExternalSecretSourceVault             ExternalSecretSourceType = "vault"
+	ExternalSecretSourceAWSSecretsManager ExternalSecretSourceType = "awsSecretsManager"
+	ExternalSecretSourceGCPSecretManager  ExternalSecretSourceType = "gcpSecretManager"
+)
+
+// ExternalSecretSource configures where the operator fetches the sys users
+// credential material from when it doesn't live in a corev1.Secret.
+type ExternalSecretSource struct {
+	Type ExternalSecretSourceType `json:"type"`
+
+	Vault             *VaultSecretSource       `json:"vault,omitempty"`
+	AWSSecretsManager *AWSSecretsManagerSource `json:"awsSecretsManager,omitempty"`
+	GCPSecretManager  *GCPSecretManagerSource  `json:"gcpSecretManager,omitempty"`
+}
+
+// VaultSecretSource reads a KV v2 secret from HashiCorp Vault.
+type VaultSecretSource struct {
+	Addr string `json:"addr"`
+	Path string `json:"path"`
+
+	// AuthMethod is "token" (default) or "kubernetes".
+	AuthMethod string `json:"authMethod,omitempty"`
+	// Role is the Vault role to use with the kubernetes auth method.
+	Role string `json:"role,omitempty"`
+	// TokenSecretRef points to the Secret key holding a static Vault token,
+	// used with the token auth method.
+	TokenSecretRef *SecretKeySelector `json:"tokenSecretRef,omitempty"`
+}
+
+// AWSSecretsManagerSource reads a JSON key/value secret from AWS Secrets Manager.
+type AWSSecretsManagerSource struct {
+	Region   string `json:"region"`
+	SecretID string `json:"secretId"`
+}
+
+// GCPSecretManagerSource reads a JSON key/value secret from GCP Secret Manager.
+type GCPSecretManagerSource struct {
+	ProjectID string `json:"projectId"`
+	SecretID  string `json:"secretId"`
+	// Version defaults to "latest".
+	Version string `json:"version,omitempty"`
+}
+
+// PerconaServerMongoDBSpec defines the desired state of PerconaServerMongoDB
+type PerconaServerMongoDBSpec struct {
+	Replsets []*ReplsetSpec `json:"replsets,omitempty"`
+	Secrets  SecretsSpec    `json:"secrets,omitempty"`
+	PMM      PMMSpec        `json:"pmm,omitempty"`
+	Sharding ShardingSpec   `json:"sharding,omitempty"`
+}
+
+// ClusterConditionType identifies a specific aspect of cluster reconciliation
+// reported via PerconaServerMongoDBStatus.Conditions.
+type ClusterConditionType string
+
+const (
+	// ClusterConditionUsersReconciled reports the outcome of the most recent
+	// sys user create/update/drop pass, see reconcileUsers.
+	ClusterConditionUsersReconciled ClusterConditionType = "UsersReconciled"
+)
+
+// ClusterCondition is a single observation of some aspect of cluster state,
+// following the same Type/Status/Reason/Message/LastTransitionTime shape
+// used throughout the Kubernetes API conventions.
+type ClusterCondition struct {
+	Type               ClusterConditionType   `json:"type"`
+	Status             corev1.ConditionStatus `json:"status"`
+	Reason             string                 `json:"reason,omitempty"`
+	Message            string                 `json:"message,omitempty"`
+	LastTransitionTime metav1.Time            `json:"lastTransitionTime,omitempty"`
+}
+
+// PerconaServerMongoDBStatus defines the observed state of PerconaServerMongoDB
+type PerconaServerMongoDBStatus struct {
+	State      AppState           `json:"state,omitempty"`
+	Conditions []ClusterCondition `json:"conditions,omitempty"`
+
+	// AppliedSecretHash is the sha256 hash of the cr.Spec.Secrets.Users data
+	// currently applied to the cluster, i.e. the last-applied-secret
+	// annotation on the internal-<name>-users Secret, so admins can tell
+	// from `kubectl describe` whether the running cluster matches it.
+	AppliedSecretHash string `json:"appliedSecretHash,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaServerMongoDB is the Schema for the perconaservermongodbs API
+type PerconaServerMongoDB struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PerconaServerMongoDBSpec   `json:"spec,omitempty"`
+	Status PerconaServerMongoDBStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaServerMongoDBList contains a list of PerconaServerMongoDB
+type PerconaServerMongoDBList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PerconaServerMongoDB `json:"items"`
+}
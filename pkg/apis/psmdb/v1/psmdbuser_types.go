@@ -0,0 +1,82 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UserRole grants a MongoDB role (built-in or custom) to a user on a
+// specific database, mirroring the role documents accepted by
+// grantRolesToUser/revokeRolesFromUser.
+type UserRole struct {
+	Role string `json:"role"`
+	DB   string `json:"db"`
+}
+
+// SecretKeySelector selects a key of a Secret in the PerconaServerMongoDBUser's
+// namespace.
+type SecretKeySelector struct {
+	Name string `json:"name"`
+	Key  string `json:"key,omitempty"`
+}
+
+// PerconaServerMongoDBUserSpec defines the desired state of a single
+// application user living inside a PerconaServerMongoDB cluster.
+type PerconaServerMongoDBUserSpec struct {
+	// ClusterName is the name of the PerconaServerMongoDB this user belongs to.
+	ClusterName string `json:"clusterName"`
+
+	// DB is the database the user authenticates against.
+	DB string `json:"db"`
+
+	// UserName is the MongoDB user name. Defaults to metadata.name if empty.
+	UserName string `json:"userName,omitempty"`
+
+	// SecretRef points to the Secret holding the user's credentials. The
+	// password is read from PasswordKey (default "password") and generated
+	// and stored there if the Secret doesn't exist yet.
+	SecretRef SecretKeySelector `json:"secretRef"`
+
+	// Roles is the list of roles granted to the user.
+	Roles []UserRole `json:"roles"`
+}
+
+// MongoDBUserState describes where a PerconaServerMongoDBUser is in its
+// reconciliation lifecycle.
+type MongoDBUserState string
+
+const (
+	MongoDBUserStatePending MongoDBUserState = "pending"
+	MongoDBUserStateReady   MongoDBUserState = "ready"
+	MongoDBUserStateError   MongoDBUserState = "error"
+)
+
+// PerconaServerMongoDBUserStatus defines the observed state of
+// PerconaServerMongoDBUser.
+type PerconaServerMongoDBUserStatus struct {
+	State   MongoDBUserState `json:"state,omitempty"`
+	Message string           `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaServerMongoDBUser is the Schema for the perconaservermongodbusers API
+type PerconaServerMongoDBUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PerconaServerMongoDBUserSpec   `json:"spec,omitempty"`
+	Status PerconaServerMongoDBUserStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaServerMongoDBUserList contains a list of PerconaServerMongoDBUser
+type PerconaServerMongoDBUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PerconaServerMongoDBUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PerconaServerMongoDBUser{}, &PerconaServerMongoDBUserList{})
+}
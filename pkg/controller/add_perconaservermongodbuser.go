@@ -0,0 +1,9 @@
+package controller
+
+import (
+	"github.com/percona/percona-server-mongodb-operator/pkg/controller/perconaservermongodbuser"
+)
+
+func init() {
+	AddToManagerFuncs = append(AddToManagerFuncs, perconaservermongodbuser.Add)
+}
@@ -0,0 +1,166 @@
+package perconaservermongodb
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"time"
+
+	api "github.com/percona/percona-server-mongodb-operator/pkg/apis/psmdb/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	rotationPasswordSymbols = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	defaultRotationPassLen  = 20
+	lastRotationAnnotPrefix = "psmdb.percona.com/last-rotated-"
+)
+
+// rotationUserKeys maps the logical user names accepted in
+// cr.Spec.Secrets.PasswordRotation.Users to the Secret keys updateSysUsers
+// already reads credentials from.
+var rotationUserKeys = map[string]struct{ NameKey, PassKey string }{
+	"backup":         {envMongoDBBackupUser, envMongoDBBackupPassword},
+	"clusterAdmin":   {envMongoDBClusterAdminUser, envMongoDBClusterAdminPassword},
+	"clusterMonitor": {envMongoDBClusterMonitorUser, envMongoDBClusterMonitorPassword},
+	"userAdmin":      {envMongoDBUserAdminUser, envMongoDBUserAdminPassword},
+	"pmm":            {envPMMServerUser, envPMMServerPassword},
+}
+
+// reconcilePasswordRotation generates fresh passwords for the system users
+// due for rotation under cr.Spec.Secrets.PasswordRotation and writes them
+// into the cr.Spec.Secrets.Users Secret, letting the existing
+// sysUsersSecretDataChanged/updateSysUsers flow in reconcileUsers apply and
+// restart what's needed. It returns how long until the next user is next due
+// for rotation, and the names of the users it just generated passwords for.
+// lastRotationTime for those names is the caller's responsibility to stamp,
+// and only once updateSysUsers has actually applied the new passwords in
+// Mongo -- otherwise a Mongo-side failure would still report the user as
+// rotated "now".
+func (r *ReconcilePerconaServerMongoDB) reconcilePasswordRotation(cr *api.PerconaServerMongoDB) (rotateAfter time.Duration, rotated []string, err error) {
+	rotation := cr.Spec.Secrets.PasswordRotation
+	if rotation == nil || !rotation.Enabled {
+		return 0, nil, nil
+	}
+
+	if cr.Spec.Secrets.ExternalSource != nil {
+		// SecretSource only exposes Fetch (see pkg/psmdb/secrets), so there's
+		// nowhere for a generated password to be written back to.
+		return 0, nil, errors.New("password rotation is not supported when spec.secrets.externalSource is configured")
+	}
+
+	interval := rotation.Interval.Duration
+	if interval <= 0 {
+		return 0, nil, errors.New("password rotation interval must be positive")
+	}
+
+	length := rotation.Length
+	if length <= 0 {
+		length = defaultRotationPassLen
+	}
+
+	userNames := rotation.Users
+	if len(userNames) == 0 {
+		for name := range rotationUserKeys {
+			userNames = append(userNames, name)
+		}
+	}
+
+	internalSecret := &corev1.Secret{}
+	getErr := r.client.Get(context.TODO(),
+		types.NamespacedName{Namespace: cr.Namespace, Name: internalPrefix + cr.Name + "-users"},
+		internalSecret,
+	)
+	if k8serrors.IsNotFound(getErr) {
+		// the internal secret is only created on the first successful
+		// reconcileUsers pass; nothing to rotate against yet.
+		return interval, nil, nil
+	} else if getErr != nil {
+		return 0, nil, errors.Wrap(getErr, "get internal sys users secret")
+	}
+
+	usersSecret := &corev1.Secret{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: cr.Namespace, Name: cr.Spec.Secrets.Users}, usersSecret); err != nil {
+		return 0, nil, errors.Wrapf(err, "get sys users secret '%s'", cr.Spec.Secrets.Users)
+	}
+
+	now := time.Now()
+	nextDue := interval
+	var rotatedUsers []string
+
+	for _, name := range userNames {
+		keys, ok := rotationUserKeys[name]
+		if !ok {
+			return 0, nil, errors.Errorf("unknown password rotation user %q", name)
+		}
+
+		due := lastRotationTime(internalSecret, name).Add(interval)
+		if now.Before(due) {
+			if remaining := due.Sub(now); remaining < nextDue {
+				nextDue = remaining
+			}
+			continue
+		}
+
+		pass, err := generateRotationPassword(length)
+		if err != nil {
+			return 0, nil, errors.Wrapf(err, "generate password for %s", name)
+		}
+		usersSecret.Data[keys.PassKey] = []byte(pass)
+		rotatedUsers = append(rotatedUsers, name)
+	}
+
+	if len(rotatedUsers) == 0 {
+		return nextDue, nil, nil
+	}
+
+	if err := r.client.Update(context.TODO(), usersSecret); err != nil {
+		return 0, nil, errors.Wrap(err, "update sys users secret")
+	}
+
+	return nextDue, rotatedUsers, nil
+}
+
+// markUsersRotated stamps lastRotationTime for each of the given rotation
+// user names on internalSecret's annotations. The caller must only do this
+// once it has confirmed the corresponding password change was actually
+// applied in Mongo, and must still persist internalSecret itself.
+func markUsersRotated(internalSecret *corev1.Secret, names []string, t time.Time) {
+	for _, name := range names {
+		setLastRotationTime(internalSecret, name, t)
+	}
+}
+
+func lastRotationTime(secret *corev1.Secret, user string) time.Time {
+	raw, ok := secret.Annotations[lastRotationAnnotPrefix+user]
+	if !ok {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func setLastRotationTime(secret *corev1.Secret, user string, t time.Time) {
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	secret.Annotations[lastRotationAnnotPrefix+user] = t.Format(time.RFC3339)
+}
+
+func generateRotationPassword(length int) (string, error) {
+	b := make([]byte, length)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(rotationPasswordSymbols))))
+		if err != nil {
+			return "", errors.Wrap(err, "generate random password byte")
+		}
+		b[i] = rotationPasswordSymbols[n.Int64()]
+	}
+	return string(b), nil
+}
@@ -0,0 +1,61 @@
+package perconaservermongodb
+
+import (
+	"context"
+
+	api "github.com/percona/percona-server-mongodb-operator/pkg/apis/psmdb/v1"
+
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ reconcile.Reconciler = &ReconcilePerconaServerMongoDB{}
+
+// ReconcilePerconaServerMongoDB reconciles a PerconaServerMongoDB object
+type ReconcilePerconaServerMongoDB struct {
+	client client.Client
+
+	// sfsTemplateAnnotations is stamped onto the StatefulSet pod template so a
+	// sys user rotation that needs a restart (see updateSysUsers) triggers one.
+	sfsTemplateAnnotations map[string]string
+
+	// recorder emits the Normal/Warning Events updateUsers records against
+	// the PerconaServerMongoDB object for each user create/update/drop.
+	recorder record.EventRecorder
+}
+
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcilePerconaServerMongoDB{
+		client:                 mgr.GetClient(),
+		sfsTemplateAnnotations: make(map[string]string),
+		recorder:               mgr.GetRecorder("perconaservermongodb-controller"),
+	}
+}
+
+// Reconcile reconciles the sys user credentials of a PerconaServerMongoDB
+// cluster, requeueing at rotateAfter when scheduled password rotation
+// (reconcilePasswordRotation) has a user due sooner than the next periodic
+// resync.
+func (r *ReconcilePerconaServerMongoDB) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	cr := &api.PerconaServerMongoDB{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, cr)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, errors.Wrap(err, "get PerconaServerMongoDB")
+	}
+
+	rotateAfter, err := r.reconcileUsers(cr)
+	if statusErr := r.client.Status().Update(context.TODO(), cr); statusErr != nil {
+		if err == nil {
+			err = errors.Wrap(statusErr, "update status")
+		}
+	}
+
+	return reconcile.Result{RequeueAfter: rotateAfter}, err
+}
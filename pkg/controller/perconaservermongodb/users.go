@@ -6,10 +6,13 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	api "github.com/percona/percona-server-mongodb-operator/pkg/apis/psmdb/v1"
 	"github.com/percona/percona-server-mongodb-operator/pkg/psmdb"
 	"github.com/percona/percona-server-mongodb-operator/pkg/psmdb/mongo"
+	"github.com/percona/percona-server-mongodb-operator/pkg/psmdb/secrets"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
 	corev1 "k8s.io/api/core/v1"
@@ -17,24 +20,42 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const internalPrefix = "internal-"
 
-func (r *ReconcilePerconaServerMongoDB) reconcileUsers(cr *api.PerconaServerMongoDB) error {
-	sysUsersSecretObj := corev1.Secret{}
-	err := r.client.Get(context.TODO(),
-		types.NamespacedName{
-			Namespace: cr.Namespace,
-			Name:      cr.Spec.Secrets.Users,
-		},
-		&sysUsersSecretObj,
-	)
-	if err != nil && k8serrors.IsNotFound(err) {
-		return nil
-	} else if err != nil {
-		return errors.Wrapf(err, "get sys users secret '%s'", cr.Spec.Secrets.Users)
+// reconcileUsers applies any pending sys user credential changes and returns
+// how long until the next scheduled password rotation is due, for the caller
+// to use as reconcile.Result.RequeueAfter; 0 means no rotation is scheduled.
+func (r *ReconcilePerconaServerMongoDB) reconcileUsers(cr *api.PerconaServerMongoDB) (time.Duration, error) {
+	var rotateAfter time.Duration
+	var rotatedUsers []string
+	if cr.Status.State == api.AppStateReady {
+		var err error
+		rotateAfter, rotatedUsers, err = r.reconcilePasswordRotation(cr)
+		if err != nil {
+			// A misconfigured PasswordRotation (bad interval, unknown user
+			// name, ExternalSource conflict, ...) must not take down the
+			// rest of user reconciliation -- manual Secret-edit rotation has
+			// nothing to do with the scheduled-rotation feature and should
+			// keep working. Move on with rotateAfter/rotatedUsers left at
+			// their zero values, but still surface the failure the same way
+			// a mongo-side reconcile failure would be.
+			r.setUsersReconciledCondition(cr, corev1.ConditionFalse, "RotationConfigInvalid", err.Error())
+			rotateAfter, rotatedUsers = 0, nil
+		}
+	}
+
+	sysUsersSecretObj, err := r.fetchSysUsersSecret(cr)
+	if err != nil {
+		r.setUsersReconciledCondition(cr, corev1.ConditionFalse, "SecretFetchFailed", err.Error())
+		return 0, errors.Wrap(err, "fetch sys users secret")
+	}
+	if sysUsersSecretObj == nil {
+		return rotateAfter, nil
 	}
 
 	secretName := internalPrefix + cr.Name + "-users"
@@ -48,7 +69,7 @@ func (r *ReconcilePerconaServerMongoDB) reconcileUsers(cr *api.PerconaServerMong
 		&internalSysSecretObj,
 	)
 	if err != nil && !k8serrors.IsNotFound(err) {
-		return errors.Wrap(err, "get internal sys users secret")
+		return 0, errors.Wrap(err, "get internal sys users secret")
 	}
 
 	if k8serrors.IsNotFound(err) {
@@ -59,52 +80,186 @@ func (r *ReconcilePerconaServerMongoDB) reconcileUsers(cr *api.PerconaServerMong
 		}
 		err = r.client.Create(context.TODO(), internalSysUsersSecret)
 		if err != nil {
-			return errors.Wrap(err, "create internal sys users secret")
+			return 0, errors.Wrap(err, "create internal sys users secret")
 		}
-		return nil
+		return rotateAfter, nil
 	}
 
 	// we do this check after work with secret objects because in case of upgrade cluster we need to be sure that internal secret exist
 	if cr.Status.State != api.AppStateReady {
-		return nil
+		return rotateAfter, nil
 	}
 
 	newSysData, err := json.Marshal(sysUsersSecretObj.Data)
 	if err != nil {
-		return errors.Wrap(err, "marshal sys secret data")
+		return 0, errors.Wrap(err, "marshal sys secret data")
 	}
 	newSecretDataHash := sha256Hash(newSysData)
 	dataChanged, err := sysUsersSecretDataChanged(newSecretDataHash, &internalSysSecretObj)
 	if err != nil {
-		return errors.Wrap(err, "check sys users data changes")
+		return 0, errors.Wrap(err, "check sys users data changes")
 	}
 
 	if !dataChanged {
-		return nil
+		return rotateAfter, nil
 	}
 
-	restartSfs, err := r.updateSysUsers(cr, &sysUsersSecretObj, &internalSysSecretObj)
+	restartSfs, changedUsers, err := r.updateSysUsers(cr, sysUsersSecretObj, &internalSysSecretObj)
 	if err != nil {
-		return errors.Wrap(err, "manage sys users")
+		r.setUsersReconciledCondition(cr, corev1.ConditionFalse, mutationFailureReason(err), err.Error())
+		return 0, errors.Wrap(err, "manage sys users")
 	}
 
 	internalSysSecretObj.Data = sysUsersSecretObj.Data
+	if confirmed := confirmedRotations(rotatedUsers, changedUsers); len(confirmed) > 0 {
+		// updateSysUsers above has just confirmed these rotated passwords
+		// were applied in Mongo, so it's now safe to mark them rotated.
+		markUsersRotated(&internalSysSecretObj, confirmed, time.Now())
+	}
 	err = r.client.Update(context.TODO(), &internalSysSecretObj)
 	if err != nil {
-		return errors.Wrap(err, "update internal sys users secret")
+		return 0, errors.Wrap(err, "update internal sys users secret")
 	}
 
 	if restartSfs {
 		r.sfsTemplateAnnotations["last-applied-secret"] = newSecretDataHash
 	}
 
-	return nil
+	cr.Status.AppliedSecretHash = newSecretDataHash
+	r.setUsersReconciledCondition(cr, corev1.ConditionTrue, usersReconciledReason(changedUsers), usersList(changedUsers))
+
+	return rotateAfter, nil
+}
+
+// setUsersReconciledCondition records the outcome of the most recent sys
+// user reconciliation pass on cr.Status.Conditions. The caller's top-level
+// Reconcile is responsible for persisting cr.Status once, after all of a
+// reconcile's condition updates are applied.
+func (r *ReconcilePerconaServerMongoDB) setUsersReconciledCondition(cr *api.PerconaServerMongoDB, status corev1.ConditionStatus, reason, message string) {
+	cond := api.ClusterCondition{
+		Type:               api.ClusterConditionUsersReconciled,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	}
+
+	for i := range cr.Status.Conditions {
+		if cr.Status.Conditions[i].Type == api.ClusterConditionUsersReconciled {
+			cr.Status.Conditions[i] = cond
+			return
+		}
+	}
+	cr.Status.Conditions = append(cr.Status.Conditions, cond)
+}
+
+// usersReconciledReason picks the UsersReconciled condition Reason for a
+// successful pass: PasswordRotated when every changed user kept its name,
+// UserRenamed if any of them went through the rename two-phase commit.
+func usersReconciledReason(changed []systemUser) string {
+	reason := "PasswordRotated"
+	for _, u := range changed {
+		if userChangeReason(u) == "UserRenamed" {
+			reason = "UserRenamed"
+		}
+	}
+	return reason
+}
+
+// usersList renders the usernames affected by a reconcile pass for the
+// UsersReconciled condition message.
+func usersList(changed []systemUser) string {
+	names := make([]string, 0, len(changed))
+	for _, u := range changed {
+		names = append(names, string(u.name))
+	}
+	return strings.Join(names, ", ")
+}
+
+// confirmedRotations filters rotated, the set of logical rotation user names
+// reconcilePasswordRotation decided were due, down to the ones updateSysUsers
+// actually applied in Mongo (changed). A name can be in rotated but not
+// changed if, say, "pmm" was defaulted into PasswordRotation.Users while
+// cr.Spec.PMM.Enabled is false, so updateSysUsers never touches it -- that
+// name must not be stamped as rotated.
+func confirmedRotations(rotated []string, changed []systemUser) []string {
+	changedKeys := make(map[string]struct{}, len(changed))
+	for _, u := range changed {
+		changedKeys[u.nameKey] = struct{}{}
+	}
+
+	var confirmed []string
+	for _, name := range rotated {
+		if _, ok := changedKeys[rotationUserKeys[name].NameKey]; ok {
+			confirmed = append(confirmed, name)
+		}
+	}
+	return confirmed
+}
+
+// mutationFailureReason picks the UsersReconciled/Event Reason for a failed
+// user create/update/drop: MongoDialFailed when the error looks like a dial
+// or network problem (see isRetriableMongoError), UserApplyFailed otherwise,
+// e.g. a rejected createUser/updateUser command.
+func mutationFailureReason(err error) string {
+	if isRetriableMongoError(err) {
+		return "MongoDialFailed"
+	}
+	return "UserApplyFailed"
+}
+
+// fetchSysUsersSecret returns the desired sys users credential data, either
+// from the external secret store configured in cr.Spec.Secrets.ExternalSource
+// or from the cr.Spec.Secrets.Users Secret, wrapped in a corev1.Secret so the
+// rest of reconcileUsers can treat both sources identically. It returns
+// nil, nil if neither source has data yet (e.g. the Secret doesn't exist).
+func (r *ReconcilePerconaServerMongoDB) fetchSysUsersSecret(cr *api.PerconaServerMongoDB) (*corev1.Secret, error) {
+	if cr.Spec.Secrets.ExternalSource != nil {
+		source, err := secrets.NewSource(cr.Spec.Secrets.ExternalSource, cr.Namespace, r.client)
+		if err != nil {
+			return nil, errors.Wrap(err, "build external secret source")
+		}
+
+		data, err := source.Fetch(context.TODO())
+		if err != nil {
+			return nil, errors.Wrap(err, "fetch external secret")
+		}
+
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: cr.Spec.Secrets.Users, Namespace: cr.Namespace},
+			Data:       data,
+		}, nil
+	}
+
+	sysUsersSecretObj := &corev1.Secret{}
+	err := r.client.Get(context.TODO(),
+		types.NamespacedName{
+			Namespace: cr.Namespace,
+			Name:      cr.Spec.Secrets.Users,
+		},
+		sysUsersSecretObj,
+	)
+	if err != nil && k8serrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "get sys users secret '%s'", cr.Spec.Secrets.Users)
+	}
+
+	return sysUsersSecretObj, nil
 }
 
 type systemUser struct {
+	// nameKey is the Secret data key this user's name was read from (e.g.
+	// envMongoDBClusterAdminUser), so callers can tell which logical system
+	// user this is without re-deriving it from name/currName.
+	nameKey  string
 	currName []byte
 	name     []byte
 	pass     []byte
+	// isUserAdmin marks the user the operator itself uses for the mongo
+	// connection, which needs the two-phase create-verify-drop rotation
+	// in applyUserChange.
+	isUserAdmin bool
 }
 
 type systemUsers struct {
@@ -114,7 +269,7 @@ type systemUsers struct {
 }
 
 // add appends user to su.users by given keys if user should be changed
-func (su *systemUsers) add(nameKey, passKey string) (changed bool, err error) {
+func (su *systemUsers) add(nameKey, passKey string, isUserAdmin bool) (changed bool, err error) {
 	if len(su.newData[nameKey]) == 0 {
 		return false, errors.New("undefined or not exist user name " + nameKey)
 	}
@@ -128,9 +283,11 @@ func (su *systemUsers) add(nameKey, passKey string) (changed bool, err error) {
 	}
 
 	su.users = append(su.users, systemUser{
-		currName: su.currData[nameKey],
-		name:     su.newData[nameKey],
-		pass:     su.newData[passKey],
+		nameKey:     nameKey,
+		currName:    su.currData[nameKey],
+		name:        su.newData[nameKey],
+		pass:        su.newData[passKey],
+		isUserAdmin: isUserAdmin,
 	})
 
 	return true, nil
@@ -140,7 +297,7 @@ func (su *systemUsers) len() int {
 	return len(su.users)
 }
 
-func (r *ReconcilePerconaServerMongoDB) updateSysUsers(cr *api.PerconaServerMongoDB, newUsersSec, currUsersSec *corev1.Secret) (restartSfs bool, err error) {
+func (r *ReconcilePerconaServerMongoDB) updateSysUsers(cr *api.PerconaServerMongoDB, newUsersSec, currUsersSec *corev1.Secret) (restartSfs bool, changed []systemUser, err error) {
 	su := systemUsers{
 		currData: currUsersSec.Data,
 		newData:  newUsersSec.Data,
@@ -183,105 +340,320 @@ func (r *ReconcilePerconaServerMongoDB) updateSysUsers(cr *api.PerconaServerMong
 	}
 
 	for _, u := range users {
-		changed, err := su.add(u.nameKey, u.passKey)
+		userChanged, err := su.add(u.nameKey, u.passKey, u.nameKey == envMongoDBUserAdminUser)
 		if err != nil {
-			return false, err
+			return false, nil, err
 		}
-		if u.needRestart && changed {
+		if u.needRestart && userChanged {
 			restartSfs = true
 		}
 	}
 
 	if su.len() == 0 {
-		return false, nil
+		return false, nil, nil
 	}
 
 	err = r.updateUsers(cr, su.users, string(currUsersSec.Data[envMongoDBUserAdminUser]), string(currUsersSec.Data[envMongoDBUserAdminPassword]))
 
-	return restartSfs, errors.Wrap(err, "mongo: update system users")
+	return restartSfs, su.users, errors.Wrap(err, "mongo: update system users")
+}
+
+// userRotationBackoff bounds how long a single user mutation is retried
+// before giving up and leaving the reconcile to pick it up on the next pass.
+var userRotationBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 500 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
+
+// mongoConn lazily (re-)dials a *mongo.Client, so a retry loop can recover
+// from a dropped connection or a primary stepdown without the caller having
+// to know how the client was created.
+type mongoConn struct {
+	client *mongo.Client
+	dial   func() (*mongo.Client, error)
+}
+
+func (c *mongoConn) redial() error {
+	newClient, err := c.dial()
+	if err != nil {
+		return err
+	}
+	if c.client != nil {
+		c.client.Disconnect(context.TODO())
+	}
+	c.client = newClient
+	return nil
+}
+
+// retryUserMutation runs fn against conn.client, re-dialing and retrying
+// with an exponential backoff when the failure looks transient (the primary
+// stepped down or the connection dropped), so a single conflict never aborts
+// a whole rotation and leaves credentials half-rotated.
+func retryUserMutation(conn *mongoConn, fn func(*mongo.Client) error) error {
+	return retry.OnError(userRotationBackoff, isRetriableMongoError, func() error {
+		err := fn(conn.client)
+		if err != nil && isRetriableMongoError(err) {
+			if rErr := conn.redial(); rErr != nil {
+				return err
+			}
+		}
+		return err
+	})
+}
+
+// isRetriableMongoError reports whether err looks like a transient primary
+// stepdown or network blip worth retrying, as opposed to a real mutation
+// failure (bad credentials, invalid role, etc).
+func isRetriableMongoError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	for _, s := range []string{"not master", "NotMaster", "node is recovering", "connection reset", "EOF", "i/o timeout", "no reachable servers", "connection() error"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
 }
 
+// updateUsers applies the given system user changes across the whole cluster.
+// For a sharded cluster the change is first applied on the mongos router so it
+// propagates to every shard atomically, then the config server and each shard
+// replset get their local admin-DB users updated too, since components may be
+// reached directly (e.g. by the backup agent) without going through mongos.
+// For a plain replica-set deployment only the configured replsets are touched.
 func (r *ReconcilePerconaServerMongoDB) updateUsers(cr *api.PerconaServerMongoDB, users []systemUser, adminUser, adminPass string) error {
-	for i, replset := range cr.Spec.Replsets {
-		if i > 0 {
-			log.Info("update users: multiple replica sets is not yet supported")
-			return nil
+	if cr.Spec.Sharding.Enabled {
+		mongosAddrs, err := psmdb.GetMongosAddrs(r.client, cr)
+		if err != nil {
+			return errors.Wrap(err, "get mongos addrs")
 		}
 
-		matchLabels := map[string]string{
-			"app.kubernetes.io/name":       "percona-server-mongodb",
-			"app.kubernetes.io/instance":   cr.Name,
-			"app.kubernetes.io/replset":    replset.Name,
-			"app.kubernetes.io/managed-by": "percona-server-mongodb-operator",
-			"app.kubernetes.io/part-of":    "percona-server-mongodb",
+		if len(mongosAddrs) > 0 {
+			if err := r.applyUsersAt(cr, mongosAddrs, "", "mongos", adminUser, adminPass, users); err != nil {
+				return errors.Wrap(err, "apply user changes on mongos")
+			}
 		}
+	}
 
-		pods := &corev1.PodList{}
-		err := r.client.List(context.TODO(),
-			pods,
-			&client.ListOptions{
-				Namespace:     cr.Namespace,
-				LabelSelector: labels.SelectorFromSet(matchLabels),
-			},
-		)
+	replsets := cr.Spec.Replsets
+	if cr.Spec.Sharding.Enabled && cr.Spec.Sharding.ConfigsvrReplSet != nil {
+		replsets = append([]*api.ReplsetSpec{cr.Spec.Sharding.ConfigsvrReplSet}, replsets...)
+	}
+
+	for _, replset := range replsets {
+		if err := r.updateReplsetUsers(cr, replset, users, adminUser, adminPass); err != nil {
+			return errors.Wrapf(err, "update users on replset %s", replset.Name)
+		}
+	}
+
+	return nil
+}
+
+// updateReplsetUsers applies the given system user changes against the local
+// admin DB of a single replset (a shard, the config server, or the whole
+// cluster when sharding is disabled), then verifies each rotated credential
+// against every pod of the replset.
+func (r *ReconcilePerconaServerMongoDB) updateReplsetUsers(cr *api.PerconaServerMongoDB, replset *api.ReplsetSpec, users []systemUser, adminUser, adminPass string) error {
+	matchLabels := map[string]string{
+		"app.kubernetes.io/name":       "percona-server-mongodb",
+		"app.kubernetes.io/instance":   cr.Name,
+		"app.kubernetes.io/replset":    replset.Name,
+		"app.kubernetes.io/managed-by": "percona-server-mongodb-operator",
+		"app.kubernetes.io/part-of":    "percona-server-mongodb",
+	}
+
+	pods := &corev1.PodList{}
+	err := r.client.List(context.TODO(),
+		pods,
+		&client.ListOptions{
+			Namespace:     cr.Namespace,
+			LabelSelector: labels.SelectorFromSet(matchLabels),
+		},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "get pods list for replset %s", replset.Name)
+	}
+	rsAddrs, err := psmdb.GetReplsetAddrs(r.client, cr, replset, pods.Items)
+	if err != nil {
+		return errors.Wrap(err, "get replset addr")
+	}
+
+	conn := &mongoConn{dial: dialFunc(rsAddrs, replset.Name, adminUser, adminPass)}
+	if err := conn.redial(); err != nil {
+		return errors.Wrap(err, "dial:")
+	}
+	defer conn.client.Disconnect(context.TODO())
+
+	for _, user := range users {
+		err := retryUserMutation(conn, func(c *mongo.Client) error {
+			return applyUserChange(c, user, rsAddrs, replset.Name)
+		})
 		if err != nil {
-			return errors.Wrapf(err, "get pods list for replset %s", replset.Name)
+			r.recorder.Event(cr, corev1.EventTypeWarning, mutationFailureReason(err), fmt.Sprintf("replset %s: apply user %s: %s", replset.Name, user.name, err))
+			return errors.Wrapf(err, "apply user %s", user.name)
+		}
+
+		if err := verifyUserOnPods(cr, replset, pods.Items, string(user.name), string(user.pass)); err != nil {
+			r.recorder.Event(cr, corev1.EventTypeWarning, "UserVerifyFailed", fmt.Sprintf("replset %s: verify user %s: %s", replset.Name, user.name, err))
+			return errors.Wrapf(err, "verify rotated credentials for user %s", user.name)
 		}
-		rsAddrs, err := psmdb.GetReplsetAddrs(r.client, cr, replset, pods.Items)
+
+		r.recorder.Event(cr, corev1.EventTypeNormal, userChangeReason(user), fmt.Sprintf("replset %s: user %s", replset.Name, user.name))
+	}
+
+	return nil
+}
+
+// applyUsersAt dials addrs (typically a mongos router) and applies each user
+// change with the same retry and per-connection verification guarantees as
+// updateReplsetUsers. location names where addrs points for event messages,
+// e.g. "mongos" or a replset name.
+func (r *ReconcilePerconaServerMongoDB) applyUsersAt(cr *api.PerconaServerMongoDB, addrs []string, rsName, location, adminUser, adminPass string, users []systemUser) error {
+	conn := &mongoConn{dial: dialFunc(addrs, rsName, adminUser, adminPass)}
+	if err := conn.redial(); err != nil {
+		return errors.Wrap(err, "dial")
+	}
+	defer conn.client.Disconnect(context.TODO())
+
+	for _, user := range users {
+		err := retryUserMutation(conn, func(c *mongo.Client) error {
+			return applyUserChange(c, user, addrs, rsName)
+		})
 		if err != nil {
-			return errors.Wrap(err, "get replset addr")
+			r.recorder.Event(cr, corev1.EventTypeWarning, mutationFailureReason(err), fmt.Sprintf("%s: apply user %s: %s", location, user.name, err))
+			return errors.Wrapf(err, "apply user %s", user.name)
+		}
+
+		if err := verifyUserOnAddrs(addrs, rsName, string(user.name), string(user.pass)); err != nil {
+			r.recorder.Event(cr, corev1.EventTypeWarning, "UserVerifyFailed", fmt.Sprintf("%s: verify user %s: %s", location, user.name, err))
+			return errors.Wrapf(err, "verify rotated credentials for user %s", user.name)
 		}
-		client, err := mongo.Dial(rsAddrs, replset.Name, adminUser, adminPass, true)
+
+		r.recorder.Event(cr, corev1.EventTypeNormal, userChangeReason(user), fmt.Sprintf("%s: user %s", location, user.name))
+	}
+
+	return nil
+}
+
+// userChangeReason picks the Event/condition Reason for a single applied
+// user change: PasswordRotated for an in-place password change, UserRenamed
+// for the create-verify-drop rename path in applyUserChange.
+func userChangeReason(user systemUser) string {
+	if bytes.Equal(user.currName, user.name) {
+		return "PasswordRotated"
+	}
+	return "UserRenamed"
+}
+
+// dialFunc returns a function that (re-)dials addrs, preferring a direct
+// connection and falling back to a non-direct one, matching the rest of the
+// package's dial convention.
+func dialFunc(addrs []string, rsName, user, pass string) func() (*mongo.Client, error) {
+	return func() (*mongo.Client, error) {
+		c, err := mongo.Dial(addrs, rsName, user, pass, true)
 		if err != nil {
-			client, err = mongo.Dial(rsAddrs, replset.Name, adminUser, adminPass, false)
-			if err != nil {
-				return errors.Wrap(err, "dial:")
-			}
+			return mongo.Dial(addrs, rsName, user, pass, false)
 		}
-		defer client.Disconnect(context.TODO())
+		return c, nil
+	}
+}
+
+type mUsersInfo struct {
+	Users []struct {
+		Roles interface{} `bson:"roles"`
+	} `bson:"users"`
+}
+
+// applyUserChange issues the create/update/drop commands for a single user
+// against client. Renaming a user (the rotation path for system users) first
+// creates the new name, and, for the UserAdmin used for the operator's own
+// connection, verifies the new credentials authenticate before the old name
+// is dropped -- a two-phase commit so a failure mid-rotation never locks the
+// operator out.
+func applyUserChange(client *mongo.Client, user systemUser, rsAddrs []string, rsName string) error {
+	if bytes.Equal(user.currName, user.name) {
+		res := client.Database("admin").RunCommand(context.TODO(), bson.D{{Key: "updateUser", Value: string(user.name)}, {Key: "pwd", Value: string(user.pass)}})
+		return errors.Wrapf(res.Err(), "change password for user %s", user.currName)
+	}
+
+	mu := &mUsersInfo{}
+	res := client.Database("admin").RunCommand(context.TODO(), bson.D{{Key: "usersInfo", Value: string(user.currName)}})
+	if res.Err() != nil {
+		return errors.Wrapf(res.Err(), "get infor for user %s", user.currName)
+	}
+	if err := res.Decode(mu); err != nil {
+		return errors.Wrapf(err, "decode info for user %s", user.currName)
+	}
+	var roles interface{}
+	if len(mu.Users) > 0 {
+		roles = mu.Users[0].Roles
+	}
 
-		type mUsers struct {
-			Users []struct {
-				Roles interface{} `bson:"roles"`
-			} `bson:"users"`
+	res = client.Database("admin").RunCommand(context.TODO(), bson.D{{Key: "createUser", Value: string(user.name)}, {Key: "pwd", Value: string(user.pass)}, {Key: "roles", Value: roles}})
+	if res.Err() != nil {
+		return errors.Wrapf(res.Err(), "create user %s", string(user.name))
+	}
+
+	if user.isUserAdmin {
+		if err := verifyUserOnAddrs(rsAddrs, rsName, string(user.name), string(user.pass)); err != nil {
+			return errors.Wrapf(err, "verify new user %s before dropping %s", user.name, user.currName)
 		}
+	}
 
-		for _, user := range users {
-			switch bytes.Compare(user.currName, user.name) {
-			case 0:
-				res := client.Database("admin").RunCommand(context.TODO(), bson.D{{Key: "updateUser", Value: string(user.name)}, {Key: "pwd", Value: string(user.pass)}})
-				if res.Err() != nil {
-					return errors.Wrapf(res.Err(), "change password for user %s", user.currName)
-				}
-			default:
-				mu := &mUsers{}
-				res := client.Database("admin").RunCommand(context.TODO(), bson.D{{Key: "usersInfo", Value: string(user.currName)}})
-				if res.Err() != nil {
-					return errors.Wrapf(res.Err(), "get infor for user %s", user.currName)
-				}
-				err = res.Decode(mu)
-				if err != nil {
-					return errors.Wrapf(err, "decode info for user %s", user.currName)
-				}
-				var roles interface{}
-				if len(mu.Users) > 0 {
-					roles = mu.Users[0].Roles
-				}
-				res = client.Database("admin").RunCommand(context.TODO(), bson.D{{Key: "createUser", Value: string(user.name)}, {Key: "pwd", Value: string(user.pass)}, {Key: "roles", Value: roles}})
-				if res.Err() != nil {
-					return errors.Wrapf(res.Err(), "create user %s", string(user.name))
-				}
-				res = client.Database("admin").RunCommand(context.TODO(), bson.D{{Key: "dropUser", Value: string(user.currName)}})
-				if res.Err() != nil {
-					return errors.Wrapf(res.Err(), "drop user %s", string(user.currName))
-				}
-			}
+	res = client.Database("admin").RunCommand(context.TODO(), bson.D{{Key: "dropUser", Value: string(user.currName)}})
+	if err := res.Err(); err != nil && !isUserNotFoundMongoError(err) {
+		// A sharded cluster's config server and shards only ever have the
+		// old username locally if something connected to them directly
+		// before the rename -- the mongos-routed createUser that just ran
+		// above doesn't create shard-local users on its own, so a missing
+		// old name here is expected, not a failed rotation.
+		return errors.Wrapf(err, "drop user %s", string(user.currName))
+	}
+	return nil
+}
+
+// isUserNotFoundMongoError reports whether err is the mongo driver error for
+// dropping a user that doesn't exist. This is a mongod command error, not a
+// Kubernetes API error, so it can't be matched with k8serrors.IsNotFound.
+func isUserNotFoundMongoError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "UserNotFound")
+}
+
+// verifyUserOnPods opens a fresh authenticated connection to every pod in
+// the replset using the rotated credentials, so a rotation that silently
+// failed on a subset of nodes is caught immediately instead of on the next
+// client request.
+func verifyUserOnPods(cr *api.PerconaServerMongoDB, replset *api.ReplsetSpec, pods []corev1.Pod, user, pass string) error {
+	for _, pod := range pods {
+		addr, err := psmdb.GetAddr(cr, pod.Name, replset.Name)
+		if err != nil {
+			return errors.Wrapf(err, "get addr for pod %s", pod.Name)
+		}
+		if err := verifyUserOnAddrs([]string{addr}, replset.Name, user, pass); err != nil {
+			return errors.Wrapf(err, "pod %s", pod.Name)
 		}
 	}
 
 	return nil
 }
 
+// verifyUserOnAddrs dials addrs with user/pass and pings the admin DB to
+// confirm the credentials actually authenticate.
+func verifyUserOnAddrs(addrs []string, rsName, user, pass string) error {
+	verifyClient, err := dialFunc(addrs, rsName, user, pass)()
+	if err != nil {
+		return errors.Wrap(err, "dial with rotated credentials")
+	}
+	defer verifyClient.Disconnect(context.TODO())
+
+	res := verifyClient.Database("admin").RunCommand(context.TODO(), bson.D{{Key: "ping", Value: 1}})
+	return errors.Wrap(res.Err(), "ping with rotated credentials")
+}
+
 func sysUsersSecretDataChanged(newHash string, usersSecret *corev1.Secret) (bool, error) {
 	secretData, err := json.Marshal(usersSecret.Data)
 	if err != nil {
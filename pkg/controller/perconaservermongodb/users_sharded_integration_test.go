@@ -0,0 +1,147 @@
+//go:build integration
+// +build integration
+
+package perconaservermongodb
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	api "github.com/percona/percona-server-mongodb-operator/pkg/apis/psmdb/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// These tests exercise the sharded-cluster user rotation path added to
+// updateUsers against a real two-shard cluster: a mongos router, a
+// config-server replset, and two shard replsets. They are not run by a
+// plain `go test ./...` -- they need `-tags integration` plus a live
+// cluster, since the fake k8s client used elsewhere in this package has no
+// way to stand in for real mongod/mongos processes. Point them at a
+// cluster (e.g. one stood up by the project's e2e docker-compose) with:
+//
+//	PSMDB_IT_MONGOS_ADDR         mongos router address, host:port
+//	PSMDB_IT_CONFIGSVR_ADDRS     comma-separated config-server replset addrs
+//	PSMDB_IT_SHARD0_ADDRS        comma-separated shard-0 replset addrs
+//	PSMDB_IT_SHARD1_ADDRS        comma-separated shard-1 replset addrs
+//
+// Any of them missing skips the test. applyUsersAt is exercised directly
+// against each location rather than going through updateUsers' pod-listing
+// path, since it's the same addr/location-generic mongo command path
+// updateReplsetUsers drives off of (see its doc comment) -- updateUsers only
+// adds the k8s Pod lookup on top, which isn't meaningful against addrs that
+// don't come from a real StatefulSet.
+func shardedClusterAddrs(t *testing.T) (mongos string, configsvr, shard0, shard1 []string) {
+	t.Helper()
+
+	mongos = os.Getenv("PSMDB_IT_MONGOS_ADDR")
+	configsvr = splitAddrs(os.Getenv("PSMDB_IT_CONFIGSVR_ADDRS"))
+	shard0 = splitAddrs(os.Getenv("PSMDB_IT_SHARD0_ADDRS"))
+	shard1 = splitAddrs(os.Getenv("PSMDB_IT_SHARD1_ADDRS"))
+
+	if mongos == "" || len(configsvr) == 0 || len(shard0) == 0 || len(shard1) == 0 {
+		t.Skip("PSMDB_IT_MONGOS_ADDR/PSMDB_IT_CONFIGSVR_ADDRS/PSMDB_IT_SHARD0_ADDRS/PSMDB_IT_SHARD1_ADDRS not set, skipping two-shard integration test")
+	}
+	return mongos, configsvr, shard0, shard1
+}
+
+func splitAddrs(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func testReconciler() *ReconcilePerconaServerMongoDB {
+	return &ReconcilePerconaServerMongoDB{recorder: record.NewFakeRecorder(100)}
+}
+
+// TestUpdateUsers_TwoShardCluster_PasswordRotation rotates clusterAdmin's
+// password the way updateUsers does for a sharded cluster -- on mongos
+// first, then directly against the config server's and each shard's local
+// admin DB -- and verifies the new password authenticates everywhere and
+// the old one no longer does.
+func TestUpdateUsers_TwoShardCluster_PasswordRotation(t *testing.T) {
+	mongos, configsvr, shard0, shard1 := shardedClusterAddrs(t)
+	cr := &api.PerconaServerMongoDB{}
+	r := testReconciler()
+
+	const (
+		adminUser = "userAdmin"
+		adminPass = "userAdminPass123456"
+		user      = "clusterAdmin"
+		oldPass   = "clusterAdminPass123456"
+		newPass   = "rotatedClusterAdminPass123456"
+	)
+
+	locations := []struct {
+		name   string
+		addrs  []string
+		rsName string
+	}{
+		{"mongos", []string{mongos}, ""},
+		{"configsvr", configsvr, "cfg"},
+		{"shard0", shard0, "rs0"},
+		{"shard1", shard1, "rs1"},
+	}
+
+	rotate := []systemUser{{currName: []byte(user), name: []byte(user), pass: []byte(newPass)}}
+	for _, loc := range locations {
+		if err := r.applyUsersAt(cr, loc.addrs, loc.rsName, loc.name, adminUser, adminPass, rotate); err != nil {
+			t.Fatalf("%s: apply password rotation: %s", loc.name, err)
+		}
+	}
+
+	for _, loc := range locations {
+		if err := verifyUserOnAddrs(loc.addrs, loc.rsName, user, newPass); err != nil {
+			t.Errorf("%s: new password did not authenticate: %s", loc.name, err)
+		}
+		if err := verifyUserOnAddrs(loc.addrs, loc.rsName, user, oldPass); err == nil {
+			t.Errorf("%s: old password still authenticates after rotation", loc.name)
+		}
+	}
+}
+
+// TestUpdateUsers_TwoShardCluster_RenameTolerant covers the rename path:
+// dropping the old username on the config server and shards must tolerate
+// UserNotFound, since each shard's local admin DB only has the old name at
+// all if something connected to it directly before the rename -- the
+// mongos-routed create/drop doesn't touch shard-local admin DBs on its own.
+func TestUpdateUsers_TwoShardCluster_RenameTolerant(t *testing.T) {
+	mongos, configsvr, shard0, shard1 := shardedClusterAddrs(t)
+	cr := &api.PerconaServerMongoDB{}
+	r := testReconciler()
+
+	const (
+		adminUser = "userAdmin"
+		adminPass = "userAdminPass123456"
+		oldName   = "clusterAdmin"
+		newName   = "clusterAdmin2"
+		pass      = "clusterAdminPass123456"
+	)
+
+	rename := []systemUser{{currName: []byte(oldName), name: []byte(newName), pass: []byte(pass)}}
+
+	if err := r.applyUsersAt(cr, []string{mongos}, "", "mongos", adminUser, adminPass, rename); err != nil {
+		t.Fatalf("mongos: apply rename: %s", err)
+	}
+
+	// configsvr/shard0/shard1 never had "clusterAdmin" locally, only via
+	// mongos -- the local drop must not fail with UserNotFound.
+	for _, loc := range []struct {
+		name   string
+		addrs  []string
+		rsName string
+	}{
+		{"configsvr", configsvr, "cfg"},
+		{"shard0", shard0, "rs0"},
+		{"shard1", shard1, "rs1"},
+	} {
+		if err := r.applyUsersAt(cr, loc.addrs, loc.rsName, loc.name, adminUser, adminPass, rename); err != nil {
+			t.Fatalf("%s: apply rename (expected UserNotFound on drop to be tolerated): %s", loc.name, err)
+		}
+		if err := verifyUserOnAddrs(loc.addrs, loc.rsName, newName, pass); err != nil {
+			t.Errorf("%s: renamed user did not authenticate: %s", loc.name, err)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package perconaservermongodb
+
+import (
+	"testing"
+
+	"github.com/percona/percona-server-mongodb-operator/pkg/psmdb/mongo"
+	"github.com/pkg/errors"
+)
+
+// These cover the pure decision logic around user mutation retries and the
+// create/verify/drop rename path -- isRetriableMongoError's string matching,
+// mongoConn/retryUserMutation's redial-on-retriable-error flow, and the
+// userChangeReason/isUserNotFoundMongoError branches applyUserChange picks
+// between. None of them need a live mongod/mongos, unlike
+// applyUserChange/applyUsersAt themselves, which issue real
+// createUser/updateUser/dropUser commands over a *mongo.Client and are only
+// covered by users_sharded_integration_test.go against a real cluster.
+
+func TestIsRetriableMongoError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"not master", errors.New(`server returned error: "not master"`), true},
+		{"NotMaster code name", errors.New("(NotMaster) node is not primary"), true},
+		{"node is recovering", errors.New("node is recovering and unable to accept writes"), true},
+		{"connection reset", errors.New("read tcp 10.0.0.1:27017: connection reset by peer"), true},
+		{"EOF", errors.New("unexpected EOF"), true},
+		{"i/o timeout", errors.New("dial tcp 10.0.0.1:27017: i/o timeout"), true},
+		{"no reachable servers", errors.New("no reachable servers"), true},
+		{"connection() error", errors.New("connection() error occurred during connection handshake"), true},
+		{"bad credentials", errors.New("Authentication failed"), false},
+		{"invalid role", errors.New("Unknown role: nonexistentRole"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetriableMongoError(tt.err); got != tt.want {
+				t.Errorf("isRetriableMongoError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsUserNotFoundMongoError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"user not found", errors.New("(UserNotFound) User 'clusterAdmin@admin' not found"), true},
+		{"other error", errors.New("(Unauthorized) not authorized to drop user"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isUserNotFoundMongoError(tt.err); got != tt.want {
+				t.Errorf("isUserNotFoundMongoError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserChangeReason(t *testing.T) {
+	tests := []struct {
+		name string
+		user systemUser
+		want string
+	}{
+		{"same name is a password rotation", systemUser{currName: []byte("clusterAdmin"), name: []byte("clusterAdmin")}, "PasswordRotated"},
+		{"different name is a rename", systemUser{currName: []byte("clusterAdmin"), name: []byte("clusterAdmin2")}, "UserRenamed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := userChangeReason(tt.user); got != tt.want {
+				t.Errorf("userChangeReason(%+v) = %q, want %q", tt.user, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMutationFailureReason(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"dial/network error", errors.New("no reachable servers"), "MongoDialFailed"},
+		{"rejected mongo command", errors.New("(Unauthorized) not authorized to create user"), "UserApplyFailed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mutationFailureReason(tt.err); got != tt.want {
+				t.Errorf("mutationFailureReason(%v) = %q, want %q", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeMongoConn builds a mongoConn whose dial never returns a real
+// *mongo.Client (always nil), so redial's `c.client.Disconnect` guard never
+// fires on a client that isn't backed by a live connection. dials counts how
+// many times dial was invoked.
+func fakeMongoConn(dialErrs ...error) (*mongoConn, *int) {
+	calls := 0
+	conn := &mongoConn{}
+	conn.dial = func() (*mongo.Client, error) {
+		var err error
+		if calls < len(dialErrs) {
+			err = dialErrs[calls]
+		}
+		calls++
+		return nil, err
+	}
+	return conn, &calls
+}
+
+func TestMongoConn_Redial(t *testing.T) {
+	t.Run("success leaves client set and dial called once", func(t *testing.T) {
+		conn, calls := fakeMongoConn(nil)
+		if err := conn.redial(); err != nil {
+			t.Fatalf("redial: %s", err)
+		}
+		if *calls != 1 {
+			t.Errorf("dial called %d times, want 1", *calls)
+		}
+	})
+
+	t.Run("dial failure is returned and does not touch the old client", func(t *testing.T) {
+		dialErr := errors.New("dial tcp: connection refused")
+		conn, calls := fakeMongoConn(dialErr)
+		if err := conn.redial(); err != dialErr {
+			t.Fatalf("redial() = %v, want %v", err, dialErr)
+		}
+		if *calls != 1 {
+			t.Errorf("dial called %d times, want 1", *calls)
+		}
+	})
+}
+
+func TestRetryUserMutation_RedialsOnRetriableError(t *testing.T) {
+	orig := userRotationBackoff
+	userRotationBackoff.Duration = 0
+	userRotationBackoff.Jitter = 0
+	defer func() { userRotationBackoff = orig }()
+
+	conn, dialCalls := fakeMongoConn(nil, nil, nil)
+	if err := conn.redial(); err != nil {
+		t.Fatalf("initial redial: %s", err)
+	}
+	*dialCalls = 0
+
+	fnCalls := 0
+	retriable := errors.New("node is recovering and unable to accept writes")
+	err := retryUserMutation(conn, func(c *mongo.Client) error {
+		fnCalls++
+		if fnCalls < 3 {
+			return retriable
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("retryUserMutation: %s", err)
+	}
+	if fnCalls != 3 {
+		t.Errorf("fn called %d times, want 3", fnCalls)
+	}
+	if *dialCalls != 2 {
+		t.Errorf("redial happened %d times, want 2 (once per retriable failure)", *dialCalls)
+	}
+}
+
+func TestRetryUserMutation_GivesUpOnNonRetriableError(t *testing.T) {
+	orig := userRotationBackoff
+	userRotationBackoff.Duration = 0
+	userRotationBackoff.Jitter = 0
+	defer func() { userRotationBackoff = orig }()
+
+	conn, _ := fakeMongoConn(nil)
+	if err := conn.redial(); err != nil {
+		t.Fatalf("initial redial: %s", err)
+	}
+
+	fnCalls := 0
+	wantErr := errors.New("(Unauthorized) not authorized to create user")
+	err := retryUserMutation(conn, func(c *mongo.Client) error {
+		fnCalls++
+		return wantErr
+	})
+	if err != wantErr {
+		t.Fatalf("retryUserMutation() = %v, want %v", err, wantErr)
+	}
+	if fnCalls != 1 {
+		t.Errorf("fn called %d times, want 1 (non-retriable error must not be retried)", fnCalls)
+	}
+}
@@ -0,0 +1,386 @@
+package perconaservermongodbuser
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"strings"
+
+	api "github.com/percona/percona-server-mongodb-operator/pkg/apis/psmdb/v1"
+	"github.com/percona/percona-server-mongodb-operator/pkg/psmdb"
+	"github.com/percona/percona-server-mongodb-operator/pkg/psmdb/mongo"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_perconaservermongodbuser")
+
+const (
+	userFinalizer   = "delete-psmdb-user"
+	defaultPassKey  = "password"
+	generatedPassLn = 20
+)
+
+// Add creates a new PerconaServerMongoDBUser Controller and adds it to the Manager.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcilePerconaServerMongoDBUser{client: mgr.GetClient(), scheme: mgr.GetScheme()}
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New("perconaservermongodbuser-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	return c.Watch(&source.Kind{Type: &api.PerconaServerMongoDBUser{}}, &handler.EnqueueRequestForObject{})
+}
+
+var _ reconcile.Reconciler = &ReconcilePerconaServerMongoDBUser{}
+
+// ReconcilePerconaServerMongoDBUser reconciles a PerconaServerMongoDBUser object
+type ReconcilePerconaServerMongoDBUser struct {
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+// Reconcile creates, updates or drops a MongoDB user to match the given
+// PerconaServerMongoDBUser custom resource.
+func (r *ReconcilePerconaServerMongoDBUser) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("reconciling PerconaServerMongoDBUser")
+
+	user := &api.PerconaServerMongoDBUser{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, user)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, errors.Wrap(err, "get PerconaServerMongoDBUser")
+	}
+
+	cr := &api.PerconaServerMongoDB{}
+	err = r.client.Get(context.TODO(), types.NamespacedName{Namespace: user.Namespace, Name: user.Spec.ClusterName}, cr)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "get cluster %s", user.Spec.ClusterName)
+	}
+
+	if user.DeletionTimestamp != nil {
+		return reconcile.Result{}, r.handleDelete(cr, user)
+	}
+
+	if !hasFinalizer(user) {
+		user.Finalizers = append(user.Finalizers, userFinalizer)
+		if err := r.client.Update(context.TODO(), user); err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "add finalizer")
+		}
+	}
+
+	userName, password, err := r.userCredentials(user)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "get user credentials")
+	}
+
+	mongoClient, err := r.dial(cr)
+	if err != nil {
+		return r.withErrorStatus(user, errors.Wrap(err, "dial cluster"))
+	}
+	defer mongoClient.Disconnect(context.TODO())
+
+	err = applyUser(mongoClient, user.Spec.DB, userName, password, user.Spec.Roles)
+	if err != nil {
+		return r.withErrorStatus(user, errors.Wrap(err, "apply user"))
+	}
+
+	user.Status.State = api.MongoDBUserStateReady
+	user.Status.Message = ""
+	if err := r.client.Status().Update(context.TODO(), user); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "update status")
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func (r *ReconcilePerconaServerMongoDBUser) withErrorStatus(user *api.PerconaServerMongoDBUser, cause error) (reconcile.Result, error) {
+	user.Status.State = api.MongoDBUserStateError
+	user.Status.Message = cause.Error()
+	if err := r.client.Status().Update(context.TODO(), user); err != nil {
+		log.Error(err, "update status")
+	}
+	return reconcile.Result{}, cause
+}
+
+// handleDelete drops the MongoDB user and removes the finalizer so the
+// PerconaServerMongoDBUser object can be garbage collected.
+func (r *ReconcilePerconaServerMongoDBUser) handleDelete(cr *api.PerconaServerMongoDB, user *api.PerconaServerMongoDBUser) error {
+	if !hasFinalizer(user) {
+		return nil
+	}
+
+	mongoClient, err := r.dial(cr)
+	if err != nil {
+		return errors.Wrap(err, "dial cluster")
+	}
+	defer mongoClient.Disconnect(context.TODO())
+
+	res := mongoClient.Database(user.Spec.DB).RunCommand(context.TODO(), bson.D{{Key: "dropUser", Value: userName(user)}})
+	if res.Err() != nil && !isUserNotFoundMongoError(res.Err()) {
+		return errors.Wrapf(res.Err(), "drop user %s", userName(user))
+	}
+
+	user.Finalizers = removeFinalizer(user.Finalizers)
+	return errors.Wrap(r.client.Update(context.TODO(), user), "remove finalizer")
+}
+
+// userCredentials returns the user name and password to apply, generating
+// and persisting a random password in SecretRef if it doesn't exist yet.
+func (r *ReconcilePerconaServerMongoDBUser) userCredentials(user *api.PerconaServerMongoDBUser) (name, pass string, err error) {
+	passKey := user.Spec.SecretRef.Key
+	if passKey == "" {
+		passKey = defaultPassKey
+	}
+
+	secret := &corev1.Secret{}
+	err = r.client.Get(context.TODO(), types.NamespacedName{Namespace: user.Namespace, Name: user.Spec.SecretRef.Name}, secret)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return "", "", errors.Wrap(err, "get user secret")
+	}
+
+	if k8serrors.IsNotFound(err) {
+		pass, err = generatePassword(generatedPassLn)
+		if err != nil {
+			return "", "", errors.Wrap(err, "generate password")
+		}
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      user.Spec.SecretRef.Name,
+				Namespace: user.Namespace,
+			},
+			Data: map[string][]byte{passKey: []byte(pass)},
+		}
+		if err := r.client.Create(context.TODO(), secret); err != nil {
+			return "", "", errors.Wrap(err, "create user secret")
+		}
+
+		return userName(user), pass, nil
+	}
+
+	return userName(user), string(secret.Data[passKey]), nil
+}
+
+// dial connects to the cluster the CR's user should be created against. For
+// a sharded cluster it routes through mongos, same as updateUsers in the
+// perconaservermongodb package, so the user is created cluster-wide via the
+// router instead of only on one shard's local admin DB. For a plain
+// replica-set deployment it dials the first configured replset directly.
+func (r *ReconcilePerconaServerMongoDBUser) dial(cr *api.PerconaServerMongoDB) (*mongo.Client, error) {
+	clusterAdminSecret := &corev1.Secret{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: cr.Namespace, Name: cr.Spec.Secrets.Users}, clusterAdminSecret)
+	if err != nil {
+		return nil, errors.Wrap(err, "get cluster admin secret")
+	}
+	adminUser := string(clusterAdminSecret.Data[envMongoDBUserAdminUser])
+	adminPass := string(clusterAdminSecret.Data[envMongoDBUserAdminPassword])
+
+	if cr.Spec.Sharding.Enabled {
+		mongosAddrs, err := psmdb.GetMongosAddrs(r.client, cr)
+		if err != nil {
+			return nil, errors.Wrap(err, "get mongos addrs")
+		}
+		if len(mongosAddrs) == 0 {
+			return nil, errors.New("cluster is sharded but has no mongos addresses")
+		}
+
+		return dialAddrs(mongosAddrs, "", adminUser, adminPass)
+	}
+
+	if len(cr.Spec.Replsets) == 0 {
+		return nil, errors.New("cluster has no replsets configured")
+	}
+	replset := cr.Spec.Replsets[0]
+
+	matchLabels := map[string]string{
+		"app.kubernetes.io/name":       "percona-server-mongodb",
+		"app.kubernetes.io/instance":   cr.Name,
+		"app.kubernetes.io/replset":    replset.Name,
+		"app.kubernetes.io/managed-by": "percona-server-mongodb-operator",
+		"app.kubernetes.io/part-of":    "percona-server-mongodb",
+	}
+
+	pods := &corev1.PodList{}
+	err = r.client.List(context.TODO(), pods, &client.ListOptions{
+		Namespace:     cr.Namespace,
+		LabelSelector: labels.SelectorFromSet(matchLabels),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "get pods list for replset %s", replset.Name)
+	}
+
+	rsAddrs, err := psmdb.GetReplsetAddrs(r.client, cr, replset, pods.Items)
+	if err != nil {
+		return nil, errors.Wrap(err, "get replset addr")
+	}
+
+	return dialAddrs(rsAddrs, replset.Name, adminUser, adminPass)
+}
+
+// dialAddrs dials addrs, preferring a direct connection and falling back to
+// a non-direct one, matching the rest of the operator's dial convention.
+func dialAddrs(addrs []string, rsName, user, pass string) (*mongo.Client, error) {
+	mongoClient, err := mongo.Dial(addrs, rsName, user, pass, true)
+	if err != nil {
+		mongoClient, err = mongo.Dial(addrs, rsName, user, pass, false)
+		if err != nil {
+			return nil, errors.Wrap(err, "dial:")
+		}
+	}
+
+	return mongoClient, nil
+}
+
+// applyUser creates the user if it doesn't exist yet, otherwise updates its
+// password and reconciles its role grants to match roles exactly.
+func applyUser(mongoClient *mongo.Client, db, name, pass string, roles []api.UserRole) error {
+	type mUser struct {
+		Roles []struct {
+			Role string `bson:"role"`
+			DB   string `bson:"db"`
+		} `bson:"roles"`
+	}
+	type mUsersInfo struct {
+		Users []mUser `bson:"users"`
+	}
+
+	bsonRoles := make([]bson.M, 0, len(roles))
+	for _, role := range roles {
+		bsonRoles = append(bsonRoles, bson.M{"role": role.Role, "db": role.DB})
+	}
+
+	info := &mUsersInfo{}
+	res := mongoClient.Database(db).RunCommand(context.TODO(), bson.D{{Key: "usersInfo", Value: name}})
+	if res.Err() != nil {
+		return errors.Wrapf(res.Err(), "get info for user %s", name)
+	}
+	if err := res.Decode(info); err != nil {
+		return errors.Wrapf(err, "decode info for user %s", name)
+	}
+
+	if len(info.Users) == 0 {
+		res = mongoClient.Database(db).RunCommand(context.TODO(), bson.D{
+			{Key: "createUser", Value: name},
+			{Key: "pwd", Value: pass},
+			{Key: "roles", Value: bsonRoles},
+		})
+		return errors.Wrapf(res.Err(), "create user %s", name)
+	}
+
+	res = mongoClient.Database(db).RunCommand(context.TODO(), bson.D{{Key: "updateUser", Value: name}, {Key: "pwd", Value: pass}})
+	if res.Err() != nil {
+		return errors.Wrapf(res.Err(), "update user %s", name)
+	}
+
+	current := make(map[string]bool, len(info.Users[0].Roles))
+	for _, role := range info.Users[0].Roles {
+		current[role.Role+"@"+role.DB] = true
+	}
+	wanted := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		wanted[role.Role+"@"+role.DB] = true
+	}
+
+	var grant, revoke []bson.M
+	for _, role := range roles {
+		if !current[role.Role+"@"+role.DB] {
+			grant = append(grant, bson.M{"role": role.Role, "db": role.DB})
+		}
+	}
+	for _, role := range info.Users[0].Roles {
+		if !wanted[role.Role+"@"+role.DB] {
+			revoke = append(revoke, bson.M{"role": role.Role, "db": role.DB})
+		}
+	}
+
+	if len(grant) > 0 {
+		res = mongoClient.Database(db).RunCommand(context.TODO(), bson.D{{Key: "grantRolesToUser", Value: name}, {Key: "roles", Value: grant}})
+		if res.Err() != nil {
+			return errors.Wrapf(res.Err(), "grant roles to user %s", name)
+		}
+	}
+	if len(revoke) > 0 {
+		res = mongoClient.Database(db).RunCommand(context.TODO(), bson.D{{Key: "revokeRolesFromUser", Value: name}, {Key: "roles", Value: revoke}})
+		if res.Err() != nil {
+			return errors.Wrapf(res.Err(), "revoke roles from user %s", name)
+		}
+	}
+
+	return nil
+}
+
+// isUserNotFoundMongoError reports whether err is the mongo driver error for
+// dropping a user that doesn't exist. This is a mongod command error, not a
+// Kubernetes API error, so it can't be matched with k8serrors.IsNotFound.
+func isUserNotFoundMongoError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "UserNotFound")
+}
+
+func userName(user *api.PerconaServerMongoDBUser) string {
+	if user.Spec.UserName != "" {
+		return user.Spec.UserName
+	}
+	return user.Name
+}
+
+func hasFinalizer(user *api.PerconaServerMongoDBUser) bool {
+	for _, f := range user.Finalizers {
+		if f == userFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+func removeFinalizer(finalizers []string) []string {
+	result := make([]string, 0, len(finalizers))
+	for _, f := range finalizers {
+		if f != userFinalizer {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+const (
+	passSymbols                 = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	envMongoDBUserAdminUser     = "MONGODB_USER_ADMIN_USER"
+	envMongoDBUserAdminPassword = "MONGODB_USER_ADMIN_PASSWORD"
+)
+
+func generatePassword(ln int) (string, error) {
+	b := make([]byte, ln)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(passSymbols))))
+		if err != nil {
+			return "", errors.Wrap(err, "generate random password byte")
+		}
+		b[i] = passSymbols[n.Int64()]
+	}
+	return string(b), nil
+}
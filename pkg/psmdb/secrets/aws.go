@@ -0,0 +1,37 @@
+package secrets
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/pkg/errors"
+
+	api "github.com/percona/percona-server-mongodb-operator/pkg/apis/psmdb/v1"
+)
+
+// awsSecretsManagerSource fetches the sys users secret material from a
+// single AWS Secrets Manager secret, stored as a flat JSON object.
+type awsSecretsManagerSource struct {
+	cfg *api.AWSSecretsManagerSource
+}
+
+func newAWSSecretsManagerSource(cfg *api.AWSSecretsManagerSource) *awsSecretsManagerSource {
+	return &awsSecretsManagerSource{cfg: cfg}
+}
+
+func (s *awsSecretsManagerSource) Fetch(ctx context.Context) (map[string][]byte, error) {
+	awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(s.cfg.Region))
+	if err != nil {
+		return nil, errors.Wrap(err, "load aws config")
+	}
+
+	svc := secretsmanager.NewFromConfig(awsCfg)
+	out, err := svc.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(s.cfg.SecretID)})
+	if err != nil {
+		return nil, errors.Wrapf(err, "get secret %s", s.cfg.SecretID)
+	}
+
+	return decodeJSONSecretString(aws.ToString(out.SecretString))
+}
@@ -0,0 +1,44 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"github.com/pkg/errors"
+	secretmanagerpb "google.golang.org/genproto/googleapis/cloud/secretmanager/v1"
+
+	api "github.com/percona/percona-server-mongodb-operator/pkg/apis/psmdb/v1"
+)
+
+// gcpSecretManagerSource fetches the sys users secret material from a single
+// GCP Secret Manager secret version, stored as a flat JSON object.
+type gcpSecretManagerSource struct {
+	cfg *api.GCPSecretManagerSource
+}
+
+func newGCPSecretManagerSource(cfg *api.GCPSecretManagerSource) *gcpSecretManagerSource {
+	return &gcpSecretManagerSource{cfg: cfg}
+}
+
+func (s *gcpSecretManagerSource) Fetch(ctx context.Context) (map[string][]byte, error) {
+	client, err := secretmanager.NewClient(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "create gcp secret manager client")
+	}
+	defer client.Close()
+
+	version := s.cfg.Version
+	if version == "" {
+		version = "latest"
+	}
+
+	resp, err := client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{
+		Name: fmt.Sprintf("projects/%s/secrets/%s/versions/%s", s.cfg.ProjectID, s.cfg.SecretID, version),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "access secret %s", s.cfg.SecretID)
+	}
+
+	return decodeJSONSecretString(string(resp.Payload.Data))
+}
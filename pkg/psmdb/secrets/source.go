@@ -0,0 +1,71 @@
+// Package secrets fetches the sys users credential material from an
+// external secret store (Vault, AWS Secrets Manager, GCP Secret Manager) for
+// clusters that don't keep it in a corev1.Secret.
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-server-mongodb-operator/pkg/apis/psmdb/v1"
+)
+
+// SecretSource fetches the raw key/value credential material for the sys
+// users secret from an external store, in the same shape as a
+// corev1.Secret's Data field (e.g. MONGODB_USER_ADMIN_USER -> value).
+type SecretSource interface {
+	Fetch(ctx context.Context) (map[string][]byte, error)
+}
+
+// NewSource builds the SecretSource configured by cfg.
+func NewSource(cfg *api.ExternalSecretSource, namespace string, k8sClient client.Client) (SecretSource, error) {
+	switch cfg.Type {
+	case api.ExternalSecretSourceVault:
+		if cfg.Vault == nil {
+			return nil, errors.New("vault is required when type is vault")
+		}
+		return newVaultSource(cfg.Vault, namespace, k8sClient), nil
+	case api.ExternalSecretSourceAWSSecretsManager:
+		if cfg.AWSSecretsManager == nil {
+			return nil, errors.New("awsSecretsManager is required when type is awsSecretsManager")
+		}
+		return newAWSSecretsManagerSource(cfg.AWSSecretsManager), nil
+	case api.ExternalSecretSourceGCPSecretManager:
+		if cfg.GCPSecretManager == nil {
+			return nil, errors.New("gcpSecretManager is required when type is gcpSecretManager")
+		}
+		return newGCPSecretManagerSource(cfg.GCPSecretManager), nil
+	default:
+		return nil, errors.Errorf("unknown external secret source type %q", cfg.Type)
+	}
+}
+
+// decodeJSONSecretString decodes a flat JSON object of string values, the
+// shape AWS Secrets Manager and GCP Secret Manager secrets are expected to
+// be stored in, into a Secret-like key/value map.
+func decodeJSONSecretString(raw string) (map[string][]byte, error) {
+	var kv map[string]string
+	if err := json.Unmarshal([]byte(raw), &kv); err != nil {
+		return nil, errors.Wrap(err, "decode secret payload")
+	}
+
+	data := make(map[string][]byte, len(kv))
+	for k, v := range kv {
+		data[k] = []byte(v)
+	}
+	return data, nil
+}
+
+const serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+func readServiceAccountToken() (string, error) {
+	b, err := ioutil.ReadFile(serviceAccountTokenPath)
+	if err != nil {
+		return "", errors.Wrap(err, "read service account token")
+	}
+	return string(b), nil
+}
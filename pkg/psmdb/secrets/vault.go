@@ -0,0 +1,102 @@
+package secrets
+
+import (
+	"context"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-server-mongodb-operator/pkg/apis/psmdb/v1"
+)
+
+// vaultSource fetches the sys users secret material from a KV v2 mount in
+// HashiCorp Vault, authenticating with either a static token or the
+// Kubernetes auth method.
+type vaultSource struct {
+	cfg       *api.VaultSecretSource
+	namespace string
+	k8sClient client.Client
+}
+
+func newVaultSource(cfg *api.VaultSecretSource, namespace string, k8sClient client.Client) *vaultSource {
+	return &vaultSource{cfg: cfg, namespace: namespace, k8sClient: k8sClient}
+}
+
+func (s *vaultSource) Fetch(ctx context.Context) (map[string][]byte, error) {
+	vc, err := vaultapi.NewClient(&vaultapi.Config{Address: s.cfg.Addr})
+	if err != nil {
+		return nil, errors.Wrap(err, "create vault client")
+	}
+
+	token, err := s.token(ctx, vc)
+	if err != nil {
+		return nil, errors.Wrap(err, "get vault token")
+	}
+	vc.SetToken(token)
+
+	secret, err := vc.Logical().ReadWithContext(ctx, s.cfg.Path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read secret %s", s.cfg.Path)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, errors.Errorf("no data at vault path %s", s.cfg.Path)
+	}
+
+	// KV v2 nests the actual values under "data".
+	raw, ok := secret.Data["data"].(map[string]interface{})
+	if !ok {
+		raw = secret.Data
+	}
+
+	data := make(map[string][]byte, len(raw))
+	for k, v := range raw {
+		str, ok := v.(string)
+		if !ok {
+			continue
+		}
+		data[k] = []byte(str)
+	}
+
+	return data, nil
+}
+
+func (s *vaultSource) token(ctx context.Context, vc *vaultapi.Client) (string, error) {
+	switch s.cfg.AuthMethod {
+	case "", "token":
+		if s.cfg.TokenSecretRef == nil {
+			return "", errors.New("tokenSecretRef is required for the token auth method")
+		}
+
+		sec := &corev1.Secret{}
+		err := s.k8sClient.Get(ctx, types.NamespacedName{Namespace: s.namespace, Name: s.cfg.TokenSecretRef.Name}, sec)
+		if err != nil {
+			return "", errors.Wrap(err, "get vault token secret")
+		}
+
+		key := s.cfg.TokenSecretRef.Key
+		if key == "" {
+			key = "token"
+		}
+		return string(sec.Data[key]), nil
+	case "kubernetes":
+		jwt, err := readServiceAccountToken()
+		if err != nil {
+			return "", err
+		}
+
+		resp, err := vc.Logical().WriteWithContext(ctx, "auth/kubernetes/login", map[string]interface{}{
+			"role": s.cfg.Role,
+			"jwt":  jwt,
+		})
+		if err != nil {
+			return "", errors.Wrap(err, "kubernetes auth login")
+		}
+
+		return resp.Auth.ClientToken, nil
+	default:
+		return "", errors.Errorf("unknown vault auth method %q", s.cfg.AuthMethod)
+	}
+}